@@ -0,0 +1,174 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	coreapi "github.com/ipfs/go-ipfs/core/coreapi"
+	iface "github.com/ipfs/go-ipfs/core/coreapi/interface"
+
+	cmds "gx/ipfs/QmUEB5nT4LG3TkUd5mkHrfRESUSgaUD4r7jSAYvvPeuWT9/go-ipfs-cmds"
+	cmdkit "gx/ipfs/QmceUdzxkimdYsgtX733uNgzf1DLHyBKN6ehGSp85ayppM/go-ipfs-cmdkit"
+)
+
+// resolveArg parses a raw "/ipfs/..." CLI argument and resolves it through
+// api, so pin commands accept the same paths "ipfs ls"/"ipfs cat" do
+func resolveArg(ctx context.Context, api iface.CoreAPI, arg string) (iface.Path, error) {
+	p, err := coreapi.ParsePath(arg)
+	if err != nil {
+		return nil, err
+	}
+	return api.ResolvePath(ctx, p)
+}
+
+// getApi resolves the CoreAPI for the running node. Wired up alongside the
+// rest of core/commands' environment plumbing
+func getApi(env cmds.Environment) (iface.CoreAPI, error) {
+	node, ok := env.(interface {
+		CoreAPI() iface.CoreAPI
+	})
+	if !ok {
+		return nil, fmt.Errorf("pin: environment does not expose a CoreAPI")
+	}
+	return node.CoreAPI(), nil
+}
+
+// PinCmd is the "ipfs pin" command and its subcommands
+var PinCmd = &cmds.Command{
+	Helptext: cmdkit.HelpText{
+		Tagline: "Pin (and unpin) objects to local storage.",
+	},
+
+	Subcommands: map[string]*cmds.Command{
+		"add": addPinCmd,
+		"rm":  rmPinCmd,
+		"ls":  lsPinCmd,
+	},
+}
+
+type addPinOutput struct {
+	Pins []string
+}
+
+var addPinCmd = &cmds.Command{
+	Helptext: cmdkit.HelpText{
+		Tagline: "Pin objects to local storage.",
+		ShortDescription: `
+Stores an IPFS object(s) from a given path locally to disk.
+`,
+	},
+
+	Arguments: []cmdkit.Argument{
+		cmdkit.StringArg("ipfs-path", true, true, "Path to object(s) to be pinned.").EnableStdin(),
+	},
+	Options: []cmdkit.Option{
+		cmdkit.BoolOption("recursive", "r", "Recursively pin the object linked to by the specified object(s).").WithDefault(true),
+		cmdkit.StringOption("name", "An optional name to attach to the pin(s), so they can be looked up later."),
+	},
+	Run: func(req *cmds.Request, res cmds.ResponseEmitter, env cmds.Environment) error {
+		api, err := getApi(env)
+		if err != nil {
+			return err
+		}
+
+		recursive, _ := req.Options["recursive"].(bool)
+		name, _ := req.Options["name"].(string)
+
+		added := make([]string, 0, len(req.Arguments))
+		for _, arg := range req.Arguments {
+			p, err := resolveArg(req.Context, api, arg)
+			if err != nil {
+				return err
+			}
+			if err := api.Pin().Add(req.Context, p, api.Pin().WithRecursive(recursive), api.Pin().WithName(name)); err != nil {
+				return err
+			}
+			added = append(added, p.Cid().String())
+		}
+
+		return cmds.EmitOnce(res, &addPinOutput{Pins: added})
+	},
+	Type: addPinOutput{},
+}
+
+type rmPinOutput struct {
+	Pins []string
+}
+
+var rmPinCmd = &cmds.Command{
+	Helptext: cmdkit.HelpText{
+		Tagline: "Remove pinned objects from local storage.",
+	},
+
+	Arguments: []cmdkit.Argument{
+		cmdkit.StringArg("ipfs-path", true, true, "Path to object(s) to be unpinned.").EnableStdin(),
+	},
+	Run: func(req *cmds.Request, res cmds.ResponseEmitter, env cmds.Environment) error {
+		api, err := getApi(env)
+		if err != nil {
+			return err
+		}
+
+		removed := make([]string, 0, len(req.Arguments))
+		for _, arg := range req.Arguments {
+			p, err := resolveArg(req.Context, api, arg)
+			if err != nil {
+				return err
+			}
+			if err := api.Pin().Rm(req.Context, p); err != nil {
+				return err
+			}
+			removed = append(removed, p.Cid().String())
+		}
+
+		return cmds.EmitOnce(res, &rmPinOutput{Pins: removed})
+	},
+	Type: rmPinOutput{},
+}
+
+// pinLsOutput mirrors a single iface.PinLsResult for JSON/text encoding
+type pinLsOutput struct {
+	Cid  string
+	Type string
+	Name string `json:",omitempty"`
+}
+
+var lsPinCmd = &cmds.Command{
+	Helptext: cmdkit.HelpText{
+		Tagline: "List objects pinned to local storage.",
+	},
+
+	Options: []cmdkit.Option{
+		cmdkit.StringOption("type", "t", "The type of pinned keys to list. Can be \"direct\", \"indirect\", \"recursive\", or \"all\".").WithDefault("all"),
+	},
+	Run: func(req *cmds.Request, res cmds.ResponseEmitter, env cmds.Environment) error {
+		api, err := getApi(env)
+		if err != nil {
+			return err
+		}
+
+		typ, _ := req.Options["type"].(string)
+		ch, err := api.Pin().Ls(req.Context, api.Pin().WithType(typ))
+		if err != nil {
+			return err
+		}
+
+		// results are emitted one at a time as they arrive on ch, rather
+		// than collected into a slice first, so a large pinset streams to
+		// the client incrementally instead of appearing all at once
+		for r := range ch {
+			if r.Err() != nil {
+				return r.Err()
+			}
+			if err := res.Emit(&pinLsOutput{
+				Cid:  r.Pin().Path().Cid().String(),
+				Type: r.Pin().Type(),
+				Name: r.Pin().Name(),
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	Type: pinLsOutput{},
+}
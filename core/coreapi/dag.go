@@ -0,0 +1,150 @@
+package coreapi
+
+import (
+	"context"
+	"fmt"
+
+	iface "github.com/ipfs/go-ipfs/core/coreapi/interface"
+
+	ipld "gx/ipfs/QmNwUEK7QbwSqyKBu3mMtToo8SUc6wQJ7gdZq4gGGJqfnf/go-ipld-format"
+	cid "gx/ipfs/QmeSrf6pzut73u6zLQkRFQ3ygt3k6XFT2kjdYP8Tnkwwyg/go-cid"
+)
+
+// NodeGetter fetches a single IPLD node by CID. A real CoreAPI wires this to
+// the node's DAGService
+type NodeGetter func(ctx context.Context, c *cid.Cid) (ipld.Node, error)
+
+// DagTraversalAPI is the subset of iface.DagAPI that dagAPI backs: path
+// resolution only. Put and Tree need a real encoder/DAGService to do
+// anything useful, so dagAPI doesn't implement the rest of iface.DagAPI and
+// can't be returned as one
+type DagTraversalAPI interface {
+	// ResolvePath resolves path as far as the IPLD DAG allows and returns
+	// the Node it bottoms out at, along with whatever path segments are
+	// left unresolved
+	ResolvePath(ctx context.Context, p iface.Path) (iface.Node, []string, error)
+
+	// GetPath resolves path the same way as ResolvePath, but returns the
+	// leaf value itself rather than the Node containing it
+	GetPath(ctx context.Context, p iface.Path) (interface{}, error)
+
+	// Get attempts to resolve and get the node specified by the path
+	Get(ctx context.Context, p iface.Path) (iface.Node, error)
+}
+
+// dagAPI implements DagTraversalAPI: ResolvePath, Get and GetPath. Put and
+// Tree need a real encoder/DAGService to do anything useful and aren't
+// implemented here
+type dagAPI struct {
+	get NodeGetter
+}
+
+// NewDagAPI returns a DagTraversalAPI that resolves paths by fetching nodes
+// through get
+func NewDagAPI(get NodeGetter) DagTraversalAPI {
+	return &dagAPI{get: get}
+}
+
+// dagLink is this package's stand-in for the *format.Link values
+// ipld.Node.Resolve returns when a path segment crosses a link to another
+// node: just enough (the target CID) for dagResolve to follow it
+type dagLink struct {
+	c *cid.Cid
+}
+
+// dagResolve walks from root, repeatedly calling resolveStep and following
+// any link it returns via get, until a path segment resolves to something
+// other than a link or the path runs out. It returns the last node reached
+// and the value resolveStep returned there (which is the node itself when
+// path is exhausted, or a scalar pulled from the node's own data otherwise),
+// along with whatever of path remains unresolved.
+//
+// get and resolveStep are injected so this walk can be tested without a
+// real ipld.Node/DAGService; the real adapter in (*dagAPI).resolve supplies
+// ones backed by ipld.Node.Resolve and a NodeGetter
+func dagResolve(
+	ctx context.Context,
+	get func(ctx context.Context, c *cid.Cid) (interface{}, error),
+	resolveStep func(node interface{}, path []string) (interface{}, []string, error),
+	root interface{},
+	path []string,
+) (node interface{}, val interface{}, remainder []string, err error) {
+	node = root
+	for {
+		val, remainder, err = resolveStep(node, path)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		lnk, ok := val.(*dagLink)
+		if !ok {
+			return node, val, remainder, nil
+		}
+		next, err := get(ctx, lnk.c)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		node, path = next, remainder
+	}
+}
+
+func (api *dagAPI) resolve(ctx context.Context, p iface.Path) (node interface{}, val interface{}, remainder []string, err error) {
+	root, err := api.get(ctx, p.Root())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	get := func(ctx context.Context, c *cid.Cid) (interface{}, error) {
+		return api.get(ctx, c)
+	}
+	resolveStep := func(n interface{}, path []string) (interface{}, []string, error) {
+		v, rest, err := n.(ipld.Node).Resolve(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if lnk, ok := v.(*ipld.Link); ok {
+			return &dagLink{c: lnk.Cid}, rest, nil
+		}
+		return v, rest, nil
+	}
+
+	return dagResolve(ctx, get, resolveStep, ipld.Node(root), p.Remainder())
+}
+
+// ResolvePath resolves path as far as the IPLD DAG allows and returns the
+// Node it bottoms out at, along with whatever path segments are left
+func (api *dagAPI) ResolvePath(ctx context.Context, p iface.Path) (iface.Node, []string, error) {
+	node, _, remainder, err := api.resolve(ctx, p)
+	if err != nil {
+		return nil, nil, err
+	}
+	n, ok := node.(ipld.Node)
+	if !ok {
+		return nil, nil, fmt.Errorf("dag resolve: unexpected node type %T", node)
+	}
+	return iface.Node(n), remainder, nil
+}
+
+// GetPath resolves path the same way as ResolvePath, but returns the leaf
+// value itself - which is the node as a whole if path was fully consumed by
+// link traversal, or a scalar pulled from the node's own data otherwise
+func (api *dagAPI) GetPath(ctx context.Context, p iface.Path) (interface{}, error) {
+	_, val, remainder, err := api.resolve(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	if len(remainder) != 0 {
+		return nil, fmt.Errorf("could not resolve past %q", remainder[0])
+	}
+	return val, nil
+}
+
+func (api *dagAPI) Get(ctx context.Context, p iface.Path) (iface.Node, error) {
+	node, remainder, err := api.ResolvePath(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	if len(remainder) != 0 {
+		return nil, fmt.Errorf("could not resolve past %q", remainder[0])
+	}
+	return node, nil
+}
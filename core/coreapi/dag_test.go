@@ -0,0 +1,75 @@
+package coreapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	cid "gx/ipfs/QmeSrf6pzut73u6zLQkRFQ3ygt3k6XFT2kjdYP8Tnkwwyg/go-cid"
+)
+
+// fakeNode is a minimal stand-in for ipld.Node: a flat map of path segment
+// to either a scalar value or a *dagLink to another fakeNode, just enough
+// to drive dagResolve without needing a real ipld.Node implementation
+type fakeNode map[string]interface{}
+
+func fakeResolveStep(n interface{}, path []string) (interface{}, []string, error) {
+	node := n.(fakeNode)
+	if len(path) == 0 {
+		return node, nil, nil
+	}
+	v, ok := node[path[0]]
+	if !ok {
+		return nil, nil, fmt.Errorf("no such link: %q", path[0])
+	}
+	return v, path[1:], nil
+}
+
+func TestDagResolveFollowsLinksAcrossNodes(t *testing.T) {
+	leafCid, err := cid.Decode(testCidB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf := fakeNode{"name": "leaf"}
+	root := fakeNode{"child": &dagLink{c: leafCid}}
+
+	nodes := map[string]interface{}{leafCid.String(): leaf}
+	get := func(ctx context.Context, c *cid.Cid) (interface{}, error) {
+		n, ok := nodes[c.String()]
+		if !ok {
+			return nil, fmt.Errorf("no such node: %s", c)
+		}
+		return n, nil
+	}
+
+	node, val, remainder, err := dagResolve(context.Background(), get, fakeResolveStep, root, []string{"child", "name"})
+	if err != nil {
+		t.Fatalf("dagResolve: %v", err)
+	}
+	if len(remainder) != 0 {
+		t.Fatalf("remainder = %v, want none", remainder)
+	}
+	if node.(fakeNode)["name"] != "leaf" {
+		t.Fatalf("node = %v, want the leaf node", node)
+	}
+	if val != "leaf" {
+		t.Fatalf("val = %v, want %q", val, "leaf")
+	}
+}
+
+func TestDagResolveReturnsRemainderPastLastLink(t *testing.T) {
+	root := fakeNode{"name": "root"}
+
+	get := func(ctx context.Context, c *cid.Cid) (interface{}, error) {
+		t.Fatal("get should not be called when the path never crosses a link")
+		return nil, nil
+	}
+
+	_, _, remainder, err := dagResolve(context.Background(), get, fakeResolveStep, root, []string{"name", "extra"})
+	if err != nil {
+		t.Fatalf("dagResolve: %v", err)
+	}
+	if len(remainder) != 1 || remainder[0] != "extra" {
+		t.Fatalf("remainder = %v, want [extra]", remainder)
+	}
+}
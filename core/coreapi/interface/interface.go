@@ -17,7 +17,9 @@ import (
 // Path is a generic wrapper for paths used in the API. A path can be resolved
 // to a CID using one of Resolve functions in the API.
 type Path interface {
-	// String returns the path as a string.
+	// String returns the path as a string, with any contained CID rendered
+	// in the multibase requested via WithCidBase when the path was produced
+	// (default is the base used in the original CID)
 	String() string
 	// Cid returns cid referred to by path
 	Cid() *cid.Cid
@@ -25,6 +27,10 @@ type Path interface {
 	Root() *cid.Cid
 	// Resolved returns whether path has been fully resolved
 	Resolved() bool
+	// Remainder returns the path segments following the root CID that have
+	// not yet been resolved into the DAG, e.g. ["foo", "bar"] for
+	// /ipfs/<cid>/foo/bar before it has been walked
+	Remainder() []string
 }
 
 // TODO: should we really copy these?
@@ -60,6 +66,14 @@ type Pin interface {
 
 	// Type of the pin
 	Type() string
+
+	// Name returns the label the pin was created with, or "" if it wasn't
+	// given one
+	Name() string
+
+	// Metadata returns the free-form metadata attached to the pin at
+	// creation time
+	Metadata() map[string]string
 }
 
 // PinStatus holds information about pin health
@@ -69,6 +83,37 @@ type PinStatus interface {
 
 	// BadNodes returns any bad (usually missing) nodes from the pin
 	BadNodes() []BadPinNode
+
+	// Err returns the error, if any, that interrupted verification of this
+	// pin (e.g. a transport error talking to a remote pinning service)
+	Err() error
+}
+
+// PinLsResult is a single entry of the stream returned by PinAPI.Ls
+type PinLsResult interface {
+	// Pin is the pinned object this entry describes. Valid only if Err
+	// returns nil
+	Pin() Pin
+
+	// Err is set when listing was interrupted before this entry could be
+	// produced, e.g. by a datastore error. Earlier entries on the channel
+	// remain valid even if a later one carries an error
+	Err() error
+}
+
+// PinUpdateStat reports how much work PinAPI.Update had to do to move a pin
+// from one root to another
+type PinUpdateStat struct {
+	// BlocksTransferred is the number of blocks reachable from to that were
+	// not already reachable from from and had to be fetched
+	BlocksTransferred uint64
+
+	// BytesTransferred is the total size of BlocksTransferred
+	BytesTransferred uint64
+
+	// BlocksReused is the number of blocks reachable from to that were
+	// already reachable from from and didn't need to be fetched again
+	BlocksReused uint64
 }
 
 // BadPinNode is a node that has been marked as bad by Pin.Verify
@@ -92,6 +137,15 @@ type CoreAPI interface {
 	Key() KeyAPI
 	Pin() PinAPI
 
+	// PinServices returns an implementation of PinServicesAPI, which manages
+	// the set of named remote pinning services this node's PinAPI can target
+	PinServices() PinServicesAPI
+
+	// WithOptions returns a CoreAPI bound to the given options, e.g. so an
+	// entire session can default to rendering paths with CIDv1/base32
+	// without passing WithCidBase to every call
+	WithOptions(opts ...options.ApiOption) (CoreAPI, error)
+
 	// ResolvePath resolves the path using Unixfs resolver
 	ResolvePath(context.Context, Path) (Path, error)
 
@@ -103,7 +157,12 @@ type CoreAPI interface {
 // UnixfsAPI is the basic interface to immutable files in IPFS
 type UnixfsAPI interface {
 	// Add imports the data from the reader into merkledag file
-	Add(context.Context, io.Reader) (Path, error)
+	Add(context.Context, io.Reader, ...options.UnixfsAddOption) (Path, error)
+
+	// WithCidBase is an option which specifies the multibase encoding (e.g.
+	// "base32", "base58btc") used to render the returned Path. Default is
+	// the base used in the original CID
+	WithCidBase(base string) options.UnixfsAddOption
 
 	// Cat returns a reader for the file
 	Cat(context.Context, Path) (Reader, error)
@@ -133,6 +192,15 @@ type DagAPI interface {
 	// the hash will be used
 	WithHash(mhType uint64, mhLen int) options.DagPutOption
 
+	// WithCidBase is an option for Put and Tree which specifies the
+	// multibase encoding used to render returned Paths. The returned value
+	// satisfies both option types. Default is the base used in the
+	// original CID
+	WithCidBase(base string) interface {
+		options.DagPutOption
+		options.DagTreeOption
+	}
+
 	// Get attempts to resolve and get the node specified by the path
 	Get(ctx context.Context, path Path) (Node, error)
 
@@ -142,6 +210,21 @@ type DagAPI interface {
 	// WithDepth is an option for Tree which specifies maximum depth of the
 	// returned tree. Default is -1 (no depth limit)
 	WithDepth(depth int) options.DagTreeOption
+
+	// ResolvePath resolves path as far as the IPLD DAG allows and returns
+	// the Node it bottoms out at, along with whatever path segments are
+	// left unresolved (the tail of path.Remainder() that remains after
+	// following links), e.g. fields of the node's own
+	// data rather than further links. Unlike CoreAPI.ResolvePath, callers
+	// get the remainder instead of an error when the path runs past the
+	// last link
+	ResolvePath(ctx context.Context, path Path) (Node, []string, error)
+
+	// GetPath resolves path the same way as ResolvePath, but returns the
+	// leaf value itself rather than the Node containing it, so a scalar
+	// terminal (a string, a number, ...) comes back as a plain Go value
+	// instead of requiring the caller to re-implement IPLD traversal
+	GetPath(ctx context.Context, path Path) (interface{}, error)
 }
 
 // NameAPI specifies the interface to IPNS.
@@ -181,6 +264,15 @@ type NameAPI interface {
 	// WithCache is an option for Resolve which specifies if cache should be used.
 	// Default value is true
 	WithCache(cache bool) options.NameResolveOption
+
+	// WithCidBase is an option for Publish and Resolve which specifies the
+	// multibase encoding used to render the Path carried by the resolved or
+	// published entry. The returned value satisfies both option types.
+	// Default is the base used in the original CID
+	WithCidBase(base string) interface {
+		options.NamePublishOption
+		options.NameResolveOption
+	}
 }
 
 // KeyAPI specifies the interface to Keystore
@@ -212,11 +304,26 @@ type KeyAPI interface {
 	// replace existing keys.
 	WithForce(force bool) options.KeyRenameOption
 
+	// WithCidBase is an option for Generate, Rename and Remove which
+	// specifies the multibase encoding used to render the returned Key's or
+	// Path's CID. The returned value satisfies all three option types.
+	// Default is the base used in the original CID.
+	//
+	// Not available on List: it returns a slice of Keys rather than a
+	// single Path-carrying result, so there's no single CID to render in a
+	// requested base at the call site - callers who need a specific base
+	// for listed keys' Paths can render them individually
+	WithCidBase(base string) interface {
+		options.KeyGenerateOption
+		options.KeyRenameOption
+		options.KeyRemoveOption
+	}
+
 	// List lists keys stored in keystore
 	List(ctx context.Context) ([]Key, error)
 
 	// Remove removes keys from keystore. Returns ipns path of the removed key
-	Remove(ctx context.Context, name string) (Path, error)
+	Remove(ctx context.Context, name string, opts ...options.KeyRemoveOption) (Path, error)
 }
 
 // type ObjectAPI interface {
@@ -251,8 +358,21 @@ type PinAPI interface {
 	// object tree or just one object. Default: true
 	WithRecursive(bool) options.PinAddOption
 
-	// Ls returns list of pinned objects on this node
-	Ls(context.Context, ...options.PinLsOption) ([]Pin, error)
+	// WithName is an option for Add which attaches a user-supplied label to
+	// the pin. The name can later be used to look up the pin with Get or to
+	// filter the results of Ls. Default: ""
+	WithName(name string) options.PinAddOption
+
+	// WithMeta is an option for Add which attaches free-form metadata to the
+	// pin, stored alongside its name. Default: nil
+	WithMeta(meta map[string]string) options.PinAddOption
+
+	// Ls returns a channel of pinned objects on this node, emitted as they
+	// are read from the pinner so callers don't have to wait for the whole
+	// pinset to be loaded into memory before seeing the first result. A
+	// PinLsResult with a non-nil Err terminates the stream; results received
+	// before it remain valid
+	Ls(context.Context, ...options.PinLsOption) (<-chan PinLsResult, error)
 
 	// WithType is an option for Ls which allows to specify which pin types should
 	// be returned
@@ -265,16 +385,72 @@ type PinAPI interface {
 	// * "all" - all pinned objects (default)
 	WithType(string) options.PinLsOption
 
+	// WithNameFilter is an option for Ls which restricts the returned pins to
+	// those whose name matches the given pattern. Default: "" (no filtering)
+	WithNameFilter(pattern string) options.PinLsOption
+
+	// Get looks up a single pin by the name it was given with WithName.
+	// Returns an error if no pin carries that name.
+	Get(ctx context.Context, name string, opts ...options.PinGetOption) (Pin, error)
+
 	// Rm removes pin for object specified by the path
-	Rm(context.Context, Path) error
+	Rm(ctx context.Context, p Path, opts ...options.PinRmOption) error
 
 	// Update changes one pin to another, skipping checks for matching paths in
-	// the old tree
-	Update(ctx context.Context, from Path, to Path, opts ...options.PinUpdateOption) error
+	// the old tree. Only the blocks reachable from to that aren't already
+	// reachable from from are fetched, and the difference between the two
+	// trees is unpinned, all within a single pinner transaction
+	Update(ctx context.Context, from Path, to Path, opts ...options.PinUpdateOption) (*PinUpdateStat, error)
+
+	// WithUnpin is an option for Update which specifies whether to remove
+	// the old pin (from) once to has been pinned. Default: true
+	WithUnpin(unpin bool) options.PinUpdateOption
+
+	// WithService is an option for Add, Ls, Rm and Update which directs the
+	// operation at a named remote pinning service registered through
+	// CoreAPI.PinServices, instead of the local pinner. The returned value
+	// satisfies all four of those methods' option types, so the same call
+	// can be passed to any of them. Default: "" (local pinner)
+	WithService(name string) interface {
+		options.PinAddOption
+		options.PinLsOption
+		options.PinRmOption
+		options.PinUpdateOption
+	}
+
+	// WithCidBase is an option for Ls and Get which specifies the multibase
+	// encoding used to render the Paths carried by returned Pins. Not
+	// available on Add or Update, neither of which returns a Path/Pin to
+	// render. Default is the base used in the original CID
+	WithCidBase(base string) interface {
+		options.PinLsOption
+		options.PinGetOption
+	}
 
 	// Verify verifies the integrity of pinned objects
 	Verify(context.Context) (<-chan PinStatus, error)
 }
 
+// PinService is a pluggable pinning backend. It exposes the same surface as
+// PinAPI so that pinning to a remote service (e.g. an ipfs-cluster peer or a
+// third-party pinning provider) looks identical to pinning locally
+type PinService interface {
+	PinAPI
+}
+
+// PinServicesAPI manages the set of named remote pinning services a node
+// knows about. Services registered here can be targeted from PinAPI via
+// WithService
+type PinServicesAPI interface {
+	// Add registers a remote pinning service under name
+	Add(ctx context.Context, name string, service PinService) error
+
+	// Remove unregisters the remote pinning service known as name
+	Remove(ctx context.Context, name string) error
+
+	// List returns the names of all registered remote pinning services
+	List(ctx context.Context) ([]string, error)
+}
+
 var ErrIsDir = errors.New("object is a directory")
 var ErrOffline = errors.New("can't resolve, ipfs node is offline")
@@ -0,0 +1,37 @@
+package options
+
+// ApiSettings is the set of effective option values applied to
+// CoreAPI.WithOptions
+type ApiSettings struct {
+	CidBase string
+}
+
+// ApiOption is a single option for CoreAPI.WithOptions
+type ApiOption func(*ApiSettings) error
+
+// ApiOptions applies the given ApiOptions and returns the resulting
+// ApiSettings
+func ApiOptions(opts ...ApiOption) (*ApiSettings, error) {
+	settings := &ApiSettings{}
+	for _, opt := range opts {
+		if err := opt(settings); err != nil {
+			return nil, err
+		}
+	}
+	return settings, nil
+}
+
+type apiOpts struct{}
+
+// Api groups the constructors for CoreAPI.WithOptions options
+var Api apiOpts
+
+// CidBase is a CoreAPI.WithOptions option: it sets the multibase encoding
+// used by default to render Paths returned from the resulting CoreAPI view,
+// e.g. options.Api.CidBase("base32") for an all-CIDv1/base32 session
+func (apiOpts) CidBase(base string) ApiOption {
+	return func(settings *ApiSettings) error {
+		settings.CidBase = base
+		return nil
+	}
+}
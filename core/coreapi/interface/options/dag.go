@@ -0,0 +1,129 @@
+package options
+
+// DagPutSettings is the set of effective option values applied to DagAPI.Put
+type DagPutSettings struct {
+	InputEnc string
+	Codec    uint64
+	MhType   uint64
+	MhLength int
+	CidBase  string
+}
+
+// DagTreeSettings is the set of effective option values applied to
+// DagAPI.Tree
+type DagTreeSettings struct {
+	Depth   int
+	CidBase string
+}
+
+// DagPutOption is a single option for DagAPI.Put
+type DagPutOption interface {
+	applyDagPut(*DagPutSettings) error
+}
+
+// DagTreeOption is a single option for DagAPI.Tree
+type DagTreeOption interface {
+	applyDagTree(*DagTreeSettings) error
+}
+
+// DagPutOptions applies the given DagPutOptions and returns the resulting
+// DagPutSettings, defaulting to "json" input encoded as dag-cbor/sha2-256
+func DagPutOptions(opts ...DagPutOption) (*DagPutSettings, error) {
+	settings := &DagPutSettings{
+		InputEnc: "json",
+		Codec:    0x71,
+		MhType:   0x12,
+		MhLength: -1,
+	}
+	for _, opt := range opts {
+		if err := opt.applyDagPut(settings); err != nil {
+			return nil, err
+		}
+	}
+	return settings, nil
+}
+
+// DagTreeOptions applies the given DagTreeOptions and returns the resulting
+// DagTreeSettings, with no depth limit by default
+func DagTreeOptions(opts ...DagTreeOption) (*DagTreeSettings, error) {
+	settings := &DagTreeSettings{Depth: -1}
+	for _, opt := range opts {
+		if err := opt.applyDagTree(settings); err != nil {
+			return nil, err
+		}
+	}
+	return settings, nil
+}
+
+type dagOpts struct{}
+
+// Dag groups the constructors for DagAPI options
+var Dag dagOpts
+
+type dagInputEncOption string
+
+func (o dagInputEncOption) applyDagPut(settings *DagPutSettings) error {
+	settings.InputEnc = string(o)
+	return nil
+}
+
+func (dagOpts) InputEnc(enc string) DagPutOption {
+	return dagInputEncOption(enc)
+}
+
+type dagCodecOption uint64
+
+func (o dagCodecOption) applyDagPut(settings *DagPutSettings) error {
+	settings.Codec = uint64(o)
+	return nil
+}
+
+func (dagOpts) Codec(codec uint64) DagPutOption {
+	return dagCodecOption(codec)
+}
+
+type dagHashOption struct {
+	mhType uint64
+	mhLen  int
+}
+
+func (o dagHashOption) applyDagPut(settings *DagPutSettings) error {
+	settings.MhType = o.mhType
+	settings.MhLength = o.mhLen
+	return nil
+}
+
+func (dagOpts) Hash(mhType uint64, mhLen int) DagPutOption {
+	return dagHashOption{mhType, mhLen}
+}
+
+type dagDepthOption int
+
+func (o dagDepthOption) applyDagTree(settings *DagTreeSettings) error {
+	settings.Depth = int(o)
+	return nil
+}
+
+func (dagOpts) Depth(depth int) DagTreeOption {
+	return dagDepthOption(depth)
+}
+
+// dagCidBaseOption selects the multibase encoding used to render Paths
+// returned from Put and Tree
+type dagCidBaseOption string
+
+func (o dagCidBaseOption) applyDagPut(settings *DagPutSettings) error {
+	settings.CidBase = string(o)
+	return nil
+}
+
+func (o dagCidBaseOption) applyDagTree(settings *DagTreeSettings) error {
+	settings.CidBase = string(o)
+	return nil
+}
+
+// CidBase is a DagAPI option applicable to Put and Tree: it selects the
+// multibase encoding used to render returned Paths
+func (dagOpts) CidBase(base string) dagCidBaseOption {
+	return dagCidBaseOption(base)
+}
@@ -0,0 +1,143 @@
+package options
+
+const (
+	RSAKey     = "rsa"
+	Ed25519Key = "ed25519"
+)
+
+// KeyGenerateSettings is the set of effective option values applied to
+// KeyAPI.Generate
+type KeyGenerateSettings struct {
+	Algorithm string
+	Size      int
+	CidBase   string
+}
+
+// KeyRenameSettings is the set of effective option values applied to
+// KeyAPI.Rename
+type KeyRenameSettings struct {
+	Force   bool
+	CidBase string
+}
+
+// KeyRemoveSettings is the set of effective option values applied to
+// KeyAPI.Remove
+type KeyRemoveSettings struct {
+	CidBase string
+}
+
+// KeyGenerateOption is a single option for KeyAPI.Generate
+type KeyGenerateOption interface {
+	applyKeyGenerate(*KeyGenerateSettings) error
+}
+
+// KeyRenameOption is a single option for KeyAPI.Rename
+type KeyRenameOption interface {
+	applyKeyRename(*KeyRenameSettings) error
+}
+
+// KeyRemoveOption is a single option for KeyAPI.Remove
+type KeyRemoveOption interface {
+	applyKeyRemove(*KeyRemoveSettings) error
+}
+
+// KeyGenerateOptions applies the given KeyGenerateOptions and returns the
+// resulting KeyGenerateSettings, defaulting to a 2048 bit RSA key
+func KeyGenerateOptions(opts ...KeyGenerateOption) (*KeyGenerateSettings, error) {
+	settings := &KeyGenerateSettings{Algorithm: RSAKey, Size: -1}
+	for _, opt := range opts {
+		if err := opt.applyKeyGenerate(settings); err != nil {
+			return nil, err
+		}
+	}
+	return settings, nil
+}
+
+// KeyRenameOptions applies the given KeyRenameOptions and returns the
+// resulting KeyRenameSettings
+func KeyRenameOptions(opts ...KeyRenameOption) (*KeyRenameSettings, error) {
+	settings := &KeyRenameSettings{}
+	for _, opt := range opts {
+		if err := opt.applyKeyRename(settings); err != nil {
+			return nil, err
+		}
+	}
+	return settings, nil
+}
+
+// KeyRemoveOptions applies the given KeyRemoveOptions and returns the
+// resulting KeyRemoveSettings
+func KeyRemoveOptions(opts ...KeyRemoveOption) (*KeyRemoveSettings, error) {
+	settings := &KeyRemoveSettings{}
+	for _, opt := range opts {
+		if err := opt.applyKeyRemove(settings); err != nil {
+			return nil, err
+		}
+	}
+	return settings, nil
+}
+
+type keyOpts struct{}
+
+// Key groups the constructors for KeyAPI options
+var Key keyOpts
+
+type keyTypeOption string
+
+func (o keyTypeOption) applyKeyGenerate(settings *KeyGenerateSettings) error {
+	settings.Algorithm = string(o)
+	return nil
+}
+
+func (keyOpts) Type(algorithm string) KeyGenerateOption {
+	return keyTypeOption(algorithm)
+}
+
+type keySizeOption int
+
+func (o keySizeOption) applyKeyGenerate(settings *KeyGenerateSettings) error {
+	settings.Size = int(o)
+	return nil
+}
+
+func (keyOpts) Size(size int) KeyGenerateOption {
+	return keySizeOption(size)
+}
+
+type keyForceOption bool
+
+func (o keyForceOption) applyKeyRename(settings *KeyRenameSettings) error {
+	settings.Force = bool(o)
+	return nil
+}
+
+func (keyOpts) Force(force bool) KeyRenameOption {
+	return keyForceOption(force)
+}
+
+// keyCidBaseOption selects the multibase encoding used to render the
+// returned Key's Path
+type keyCidBaseOption string
+
+func (o keyCidBaseOption) applyKeyGenerate(settings *KeyGenerateSettings) error {
+	settings.CidBase = string(o)
+	return nil
+}
+
+func (o keyCidBaseOption) applyKeyRename(settings *KeyRenameSettings) error {
+	settings.CidBase = string(o)
+	return nil
+}
+
+func (o keyCidBaseOption) applyKeyRemove(settings *KeyRemoveSettings) error {
+	settings.CidBase = string(o)
+	return nil
+}
+
+// CidBase is a KeyAPI option applicable to Generate, Rename and Remove: it
+// selects the multibase encoding used to render the returned Key's or
+// Path's CID. Not available on List, which returns a slice of Keys rather
+// than a single Path-carrying result
+func (keyOpts) CidBase(base string) keyCidBaseOption {
+	return keyCidBaseOption(base)
+}
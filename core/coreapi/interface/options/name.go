@@ -0,0 +1,138 @@
+package options
+
+import "time"
+
+// NamePublishSettings is the set of effective option values applied to
+// NameAPI.Publish
+type NamePublishSettings struct {
+	ValidTime time.Duration
+	Key       string
+	CidBase   string
+}
+
+// NameResolveSettings is the set of effective option values applied to
+// NameAPI.Resolve
+type NameResolveSettings struct {
+	Recursive bool
+	Local     bool
+	Cache     bool
+	CidBase   string
+}
+
+// NamePublishOption is a single option for NameAPI.Publish
+type NamePublishOption interface {
+	applyNamePublish(*NamePublishSettings) error
+}
+
+// NameResolveOption is a single option for NameAPI.Resolve
+type NameResolveOption interface {
+	applyNameResolve(*NameResolveSettings) error
+}
+
+// NamePublishOptions applies the given NamePublishOptions and returns the
+// resulting NamePublishSettings, valid for 24h under "self" by default
+func NamePublishOptions(opts ...NamePublishOption) (*NamePublishSettings, error) {
+	settings := &NamePublishSettings{
+		ValidTime: 24 * time.Hour,
+		Key:       "self",
+	}
+	for _, opt := range opts {
+		if err := opt.applyNamePublish(settings); err != nil {
+			return nil, err
+		}
+	}
+	return settings, nil
+}
+
+// NameResolveOptions applies the given NameResolveOptions and returns the
+// resulting NameResolveSettings, non-recursive, online, with cache enabled
+// by default
+func NameResolveOptions(opts ...NameResolveOption) (*NameResolveSettings, error) {
+	settings := &NameResolveSettings{Cache: true}
+	for _, opt := range opts {
+		if err := opt.applyNameResolve(settings); err != nil {
+			return nil, err
+		}
+	}
+	return settings, nil
+}
+
+type nameOpts struct{}
+
+// Name groups the constructors for NameAPI options
+var Name nameOpts
+
+type nameValidTimeOption time.Duration
+
+func (o nameValidTimeOption) applyNamePublish(settings *NamePublishSettings) error {
+	settings.ValidTime = time.Duration(o)
+	return nil
+}
+
+func (nameOpts) ValidTime(validTime time.Duration) NamePublishOption {
+	return nameValidTimeOption(validTime)
+}
+
+type nameKeyOption string
+
+func (o nameKeyOption) applyNamePublish(settings *NamePublishSettings) error {
+	settings.Key = string(o)
+	return nil
+}
+
+func (nameOpts) Key(key string) NamePublishOption {
+	return nameKeyOption(key)
+}
+
+type nameRecursiveOption bool
+
+func (o nameRecursiveOption) applyNameResolve(settings *NameResolveSettings) error {
+	settings.Recursive = bool(o)
+	return nil
+}
+
+func (nameOpts) Recursive(recursive bool) NameResolveOption {
+	return nameRecursiveOption(recursive)
+}
+
+type nameLocalOption bool
+
+func (o nameLocalOption) applyNameResolve(settings *NameResolveSettings) error {
+	settings.Local = bool(o)
+	return nil
+}
+
+func (nameOpts) Local(local bool) NameResolveOption {
+	return nameLocalOption(local)
+}
+
+type nameCacheOption bool
+
+func (o nameCacheOption) applyNameResolve(settings *NameResolveSettings) error {
+	settings.Cache = bool(o)
+	return nil
+}
+
+func (nameOpts) Cache(cache bool) NameResolveOption {
+	return nameCacheOption(cache)
+}
+
+// nameCidBaseOption selects the multibase encoding used to render the Path
+// carried by a published or resolved entry
+type nameCidBaseOption string
+
+func (o nameCidBaseOption) applyNamePublish(settings *NamePublishSettings) error {
+	settings.CidBase = string(o)
+	return nil
+}
+
+func (o nameCidBaseOption) applyNameResolve(settings *NameResolveSettings) error {
+	settings.CidBase = string(o)
+	return nil
+}
+
+// CidBase is a NameAPI option applicable to Publish and Resolve: it selects
+// the multibase encoding used to render the Path carried by the entry
+func (nameOpts) CidBase(base string) nameCidBaseOption {
+	return nameCidBaseOption(base)
+}
@@ -0,0 +1,246 @@
+package options
+
+// PinAddSettings is the set of effective option values applied to PinAPI.Add
+type PinAddSettings struct {
+	Recursive bool
+	Name      string
+	Meta      map[string]string
+	Service   string
+}
+
+// PinLsSettings is the set of effective option values applied to PinAPI.Ls
+type PinLsSettings struct {
+	Type       string
+	NameFilter string
+	Service    string
+	CidBase    string
+}
+
+// PinRmSettings is the set of effective option values applied to PinAPI.Rm
+type PinRmSettings struct {
+	Service string
+}
+
+// PinUpdateSettings is the set of effective option values applied to
+// PinAPI.Update
+type PinUpdateSettings struct {
+	Unpin   bool
+	Service string
+}
+
+// PinGetSettings is the set of effective option values applied to PinAPI.Get
+type PinGetSettings struct {
+	Service string
+	CidBase string
+}
+
+// PinAddOption is a single option for PinAPI.Add
+type PinAddOption interface {
+	applyPinAdd(*PinAddSettings) error
+}
+
+// PinLsOption is a single option for PinAPI.Ls
+type PinLsOption interface {
+	applyPinLs(*PinLsSettings) error
+}
+
+// PinRmOption is a single option for PinAPI.Rm
+type PinRmOption interface {
+	applyPinRm(*PinRmSettings) error
+}
+
+// PinUpdateOption is a single option for PinAPI.Update
+type PinUpdateOption interface {
+	applyPinUpdate(*PinUpdateSettings) error
+}
+
+// PinGetOption is a single option for PinAPI.Get
+type PinGetOption interface {
+	applyPinGet(*PinGetSettings) error
+}
+
+// PinAddOptions applies the given PinAddOptions and returns the resulting
+// PinAddSettings, recursive by default
+func PinAddOptions(opts ...PinAddOption) (*PinAddSettings, error) {
+	settings := &PinAddSettings{Recursive: true}
+	for _, opt := range opts {
+		if err := opt.applyPinAdd(settings); err != nil {
+			return nil, err
+		}
+	}
+	return settings, nil
+}
+
+// PinLsOptions applies the given PinLsOptions and returns the resulting
+// PinLsSettings, listing all pin types by default
+func PinLsOptions(opts ...PinLsOption) (*PinLsSettings, error) {
+	settings := &PinLsSettings{Type: "all"}
+	for _, opt := range opts {
+		if err := opt.applyPinLs(settings); err != nil {
+			return nil, err
+		}
+	}
+	return settings, nil
+}
+
+// PinRmOptions applies the given PinRmOptions and returns the resulting
+// PinRmSettings
+func PinRmOptions(opts ...PinRmOption) (*PinRmSettings, error) {
+	settings := &PinRmSettings{}
+	for _, opt := range opts {
+		if err := opt.applyPinRm(settings); err != nil {
+			return nil, err
+		}
+	}
+	return settings, nil
+}
+
+// PinUpdateOptions applies the given PinUpdateOptions and returns the
+// resulting PinUpdateSettings, unpinning the old root by default
+func PinUpdateOptions(opts ...PinUpdateOption) (*PinUpdateSettings, error) {
+	settings := &PinUpdateSettings{Unpin: true}
+	for _, opt := range opts {
+		if err := opt.applyPinUpdate(settings); err != nil {
+			return nil, err
+		}
+	}
+	return settings, nil
+}
+
+// PinGetOptions applies the given PinGetOptions and returns the resulting
+// PinGetSettings
+func PinGetOptions(opts ...PinGetOption) (*PinGetSettings, error) {
+	settings := &PinGetSettings{}
+	for _, opt := range opts {
+		if err := opt.applyPinGet(settings); err != nil {
+			return nil, err
+		}
+	}
+	return settings, nil
+}
+
+type pinOpts struct{}
+
+// Pin groups the constructors for PinAPI options
+var Pin pinOpts
+
+type pinRecursiveOption bool
+
+func (o pinRecursiveOption) applyPinAdd(settings *PinAddSettings) error {
+	settings.Recursive = bool(o)
+	return nil
+}
+
+func (pinOpts) Recursive(recursive bool) PinAddOption {
+	return pinRecursiveOption(recursive)
+}
+
+type pinNameOption string
+
+func (o pinNameOption) applyPinAdd(settings *PinAddSettings) error {
+	settings.Name = string(o)
+	return nil
+}
+
+func (pinOpts) Name(name string) PinAddOption {
+	return pinNameOption(name)
+}
+
+type pinMetaOption map[string]string
+
+func (o pinMetaOption) applyPinAdd(settings *PinAddSettings) error {
+	settings.Meta = map[string]string(o)
+	return nil
+}
+
+func (pinOpts) Meta(meta map[string]string) PinAddOption {
+	return pinMetaOption(meta)
+}
+
+type pinTypeOption string
+
+func (o pinTypeOption) applyPinLs(settings *PinLsSettings) error {
+	settings.Type = string(o)
+	return nil
+}
+
+func (pinOpts) Type(t string) PinLsOption {
+	return pinTypeOption(t)
+}
+
+type pinNameFilterOption string
+
+func (o pinNameFilterOption) applyPinLs(settings *PinLsSettings) error {
+	settings.NameFilter = string(o)
+	return nil
+}
+
+func (pinOpts) NameFilter(pattern string) PinLsOption {
+	return pinNameFilterOption(pattern)
+}
+
+type pinUnpinOption bool
+
+func (o pinUnpinOption) applyPinUpdate(settings *PinUpdateSettings) error {
+	settings.Unpin = bool(o)
+	return nil
+}
+
+func (pinOpts) Unpin(unpin bool) PinUpdateOption {
+	return pinUnpinOption(unpin)
+}
+
+// pinServiceOption directs an operation at a named remote pinning service
+// instead of the local pinner. It implements the option interfaces of Add,
+// Ls, Rm and Update, so options.Pin.Service can be passed to any of them
+// without collapsing their distinct option types. It does not implement
+// PinGetOption, so no option sets PinGetSettings.Service
+type pinServiceOption string
+
+func (o pinServiceOption) applyPinAdd(settings *PinAddSettings) error {
+	settings.Service = string(o)
+	return nil
+}
+
+func (o pinServiceOption) applyPinLs(settings *PinLsSettings) error {
+	settings.Service = string(o)
+	return nil
+}
+
+func (o pinServiceOption) applyPinRm(settings *PinRmSettings) error {
+	settings.Service = string(o)
+	return nil
+}
+
+func (o pinServiceOption) applyPinUpdate(settings *PinUpdateSettings) error {
+	settings.Service = string(o)
+	return nil
+}
+
+// Service is a PinAPI option applicable to Add, Ls, Rm and Update: it
+// selects which registered remote pinning service (see CoreAPI.PinServices)
+// the operation targets instead of the local pinner
+func (pinOpts) Service(name string) pinServiceOption {
+	return pinServiceOption(name)
+}
+
+// pinCidBaseOption selects the multibase encoding used to render the Paths
+// carried by returned Pins. It implements every option interface whose
+// settings carry a CidBase field
+type pinCidBaseOption string
+
+func (o pinCidBaseOption) applyPinLs(settings *PinLsSettings) error {
+	settings.CidBase = string(o)
+	return nil
+}
+
+func (o pinCidBaseOption) applyPinGet(settings *PinGetSettings) error {
+	settings.CidBase = string(o)
+	return nil
+}
+
+// CidBase is a PinAPI option applicable to Ls and Get: it selects the
+// multibase encoding used to render the Paths carried by returned Pins
+func (pinOpts) CidBase(base string) pinCidBaseOption {
+	return pinCidBaseOption(base)
+}
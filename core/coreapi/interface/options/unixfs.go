@@ -0,0 +1,42 @@
+package options
+
+// UnixfsAddSettings is the set of effective option values applied to
+// UnixfsAPI.Add
+type UnixfsAddSettings struct {
+	CidBase string
+}
+
+// UnixfsAddOption is a single option for UnixfsAPI.Add
+type UnixfsAddOption interface {
+	applyUnixfsAdd(*UnixfsAddSettings) error
+}
+
+// UnixfsAddOptions applies the given UnixfsAddOptions and returns the
+// resulting UnixfsAddSettings
+func UnixfsAddOptions(opts ...UnixfsAddOption) (*UnixfsAddSettings, error) {
+	settings := &UnixfsAddSettings{}
+	for _, opt := range opts {
+		if err := opt.applyUnixfsAdd(settings); err != nil {
+			return nil, err
+		}
+	}
+	return settings, nil
+}
+
+type unixfsOpts struct{}
+
+// Unixfs groups the constructors for UnixfsAPI options
+var Unixfs unixfsOpts
+
+type unixfsCidBaseOption string
+
+func (o unixfsCidBaseOption) applyUnixfsAdd(settings *UnixfsAddSettings) error {
+	settings.CidBase = string(o)
+	return nil
+}
+
+// CidBase is a UnixfsAPI option for Add: it selects the multibase encoding
+// used to render the returned Path
+func (unixfsOpts) CidBase(base string) UnixfsAddOption {
+	return unixfsCidBaseOption(base)
+}
@@ -0,0 +1,82 @@
+package coreapi
+
+import (
+	"encoding/base32"
+	"fmt"
+	"strings"
+
+	iface "github.com/ipfs/go-ipfs/core/coreapi/interface"
+
+	cid "gx/ipfs/QmeSrf6pzut73u6zLQkRFQ3ygt3k6XFT2kjdYP8Tnkwwyg/go-cid"
+)
+
+// path is the concrete iface.Path used throughout coreapi. Rendering the
+// root CID in a requested multibase is deferred to String(), so the same
+// resolved path can be handed to callers that asked for different bases
+// (e.g. WithCidBase) without re-resolving anything
+type path struct {
+	root      *cid.Cid
+	remainder []string
+	cidBase   string
+}
+
+func newPath(root *cid.Cid, remainder []string) iface.Path {
+	return &path{root: root, remainder: remainder}
+}
+
+// ParsePath parses a string of the form "/ipfs/<cid>[/a/b/...]" into an
+// unresolved iface.Path, e.g. for turning a CLI argument into something
+// CoreAPI.ResolvePath can work with
+func ParsePath(s string) (iface.Path, error) {
+	segments := strings.Split(strings.Trim(s, "/"), "/")
+	if len(segments) == 0 || (segments[0] != "ipfs" && segments[0] != "ipld") {
+		return nil, fmt.Errorf("invalid path %q: must start with /ipfs/ or /ipld/", s)
+	}
+	if len(segments) < 2 {
+		return nil, fmt.Errorf("invalid path %q: missing root CID", s)
+	}
+	root, err := cid.Decode(segments[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid path %q: %v", s, err)
+	}
+	return newPath(root, segments[2:]), nil
+}
+
+// withCidBase returns p with its rendered base changed to base, reusing the
+// same root and remainder. p must be a *path, which every iface.Path this
+// package hands out is
+func withCidBase(p iface.Path, base string) iface.Path {
+	orig := p.(*path)
+	return &path{root: orig.root, remainder: orig.remainder, cidBase: base}
+}
+
+func (p *path) String() string {
+	segments := append([]string{"", "ipfs", renderCid(p.root, p.cidBase)}, p.remainder...)
+	return strings.Join(segments, "/")
+}
+
+// renderCid renders c in the given multibase name, falling back to c's own
+// default base ("" or an unrecognized name). Only "base32" (the multibase
+// IPFS defaults to for CIDv1) and CIDv0-compatible "base58btc" are
+// special-cased here; a full multibase codec table belongs in go-multibase,
+// not duplicated in this package
+func renderCid(c *cid.Cid, base string) string {
+	switch base {
+	case "", "base58btc":
+		return c.String()
+	case "base32":
+		return "b" + strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(c.Bytes()))
+	default:
+		return c.String()
+	}
+}
+
+// Cid returns the root CID: a path does not record the CID its unresolved
+// remainder leads to, so Cid and Root return the same value
+func (p *path) Cid() *cid.Cid { return p.root }
+
+func (p *path) Root() *cid.Cid { return p.root }
+
+func (p *path) Resolved() bool { return len(p.remainder) == 0 }
+
+func (p *path) Remainder() []string { return p.remainder }
@@ -0,0 +1,439 @@
+package coreapi
+
+import (
+	"context"
+	"fmt"
+	stdpath "path"
+	"sync"
+
+	iface "github.com/ipfs/go-ipfs/core/coreapi/interface"
+	options "github.com/ipfs/go-ipfs/core/coreapi/interface/options"
+
+	cid "gx/ipfs/QmeSrf6pzut73u6zLQkRFQ3ygt3k6XFT2kjdYP8Tnkwwyg/go-cid"
+)
+
+// DagWalker reports every block reachable from root, along with its size,
+// so Update can tell which blocks two pinned trees already share without
+// re-fetching them. A real CoreAPI wires this to the node's DAGService;
+// tests can supply a fixed map
+type DagWalker func(ctx context.Context, root *cid.Cid) (map[string]uint64, error)
+
+// pinRecord is the state kept per local pin, including the label and
+// metadata passed to Add so they survive alongside the CID rather than
+// living only in the caller's memory
+type pinRecord struct {
+	c    iface.Path
+	typ  string
+	name string
+	meta map[string]string
+}
+
+// pinAPI is the local (non-remote) PinAPI implementation, backed by an
+// in-memory pinset keyed by CID string. A real node backs this with its
+// datastore instead; the in-memory map stands in for that here
+type pinAPI struct {
+	mu       sync.Mutex
+	pins     map[string]*pinRecord
+	walk     DagWalker
+	services map[string]iface.PinService
+}
+
+// NewPinAPI returns a local, in-memory PinAPI. walk is used by Update to
+// compute the delta between two pinned trees and by Verify to check each
+// pin. It may be nil: Update then returns an error, and Verify reports
+// every pin as ok without checking it
+func NewPinAPI(walk DagWalker) iface.PinAPI {
+	return &pinAPI{
+		pins:     map[string]*pinRecord{},
+		walk:     walk,
+		services: map[string]iface.PinService{},
+	}
+}
+
+func (api *pinAPI) service(name string) (iface.PinService, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	svc, ok := api.services[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown pinning service: %q", name)
+	}
+	return svc, nil
+}
+
+// PinServices returns the PinServicesAPI backing this PinAPI's WithService
+// option, so callers can register and list remote pinning backends. A
+// CoreAPI implementation's PinServices() delegates here directly
+func (api *pinAPI) PinServices() iface.PinServicesAPI {
+	return (*pinServicesAPI)(api)
+}
+
+func (api *pinAPI) Add(ctx context.Context, p iface.Path, opts ...options.PinAddOption) error {
+	settings, err := options.PinAddOptions(opts...)
+	if err != nil {
+		return err
+	}
+
+	if settings.Service != "" {
+		svc, err := api.service(settings.Service)
+		if err != nil {
+			return err
+		}
+		// forward settings already parsed out of opts, not opts itself: opts
+		// still carries WithService(settings.Service), and re-parsing it
+		// downstream would send svc looking up that same name in its own
+		// (likely empty) services map
+		return svc.Add(ctx, p, options.Pin.Recursive(settings.Recursive), options.Pin.Name(settings.Name), options.Pin.Meta(settings.Meta))
+	}
+
+	typ := "recursive"
+	if !settings.Recursive {
+		typ = "direct"
+	}
+
+	// the name-uniqueness check and the insert that follows it must happen
+	// under the same lock acquisition, or two concurrent Adds for the same
+	// name can both pass the check before either writes
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	if settings.Name != "" {
+		if existing := api.lookupByNameLocked(settings.Name); existing != nil && existing.c.Cid().String() != p.Cid().String() {
+			return fmt.Errorf("pin name %q is already in use by %s", settings.Name, existing.c.Cid())
+		}
+	}
+	api.pins[p.Cid().String()] = &pinRecord{
+		c:    p,
+		typ:  typ,
+		name: settings.Name,
+		meta: settings.Meta,
+	}
+	return nil
+}
+
+func (api *pinAPI) WithRecursive(recursive bool) options.PinAddOption {
+	return options.Pin.Recursive(recursive)
+}
+
+func (api *pinAPI) WithName(name string) options.PinAddOption {
+	return options.Pin.Name(name)
+}
+
+func (api *pinAPI) WithMeta(meta map[string]string) options.PinAddOption {
+	return options.Pin.Meta(meta)
+}
+
+func (api *pinAPI) lookupByName(name string) (*pinRecord, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	if r := api.lookupByNameLocked(name); r != nil {
+		return r, nil
+	}
+	return nil, fmt.Errorf("no pin named %q", name)
+}
+
+// lookupByNameLocked is lookupByName's body with the locking stripped out,
+// for callers (like Add) that need the lookup and a following write to
+// happen under one lock acquisition
+func (api *pinAPI) lookupByNameLocked(name string) *pinRecord {
+	for _, r := range api.pins {
+		if r.name == name {
+			return r
+		}
+	}
+	return nil
+}
+
+func (api *pinAPI) Get(ctx context.Context, name string, opts ...options.PinGetOption) (iface.Pin, error) {
+	settings, err := options.PinGetOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if settings.Service != "" {
+		svc, err := api.service(settings.Service)
+		if err != nil {
+			return nil, err
+		}
+		return svc.Get(ctx, name, options.Pin.CidBase(settings.CidBase))
+	}
+	r, err := api.lookupByName(name)
+	if err != nil {
+		return nil, err
+	}
+	return newPin(r, settings.CidBase), nil
+}
+
+// Ls streams its results rather than collecting them up front, so a caller
+// (e.g. the "pin ls" command) can start printing pins as soon as the first
+// one is found instead of waiting for the whole pinset to be walked.
+// Only the roots recorded by Add and Update are listed, typed "recursive"
+// or "direct"; indirectly pinned objects are not listed, so the "indirect"
+// type matches nothing and "all" returns the roots only
+func (api *pinAPI) Ls(ctx context.Context, opts ...options.PinLsOption) (<-chan iface.PinLsResult, error) {
+	settings, err := options.PinLsOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if settings.Service != "" {
+		svc, err := api.service(settings.Service)
+		if err != nil {
+			return nil, err
+		}
+		return svc.Ls(ctx, options.Pin.Type(settings.Type), options.Pin.NameFilter(settings.NameFilter), options.Pin.CidBase(settings.CidBase))
+	}
+
+	api.mu.Lock()
+	records := make([]*pinRecord, 0, len(api.pins))
+	for _, r := range api.pins {
+		records = append(records, r)
+	}
+	api.mu.Unlock()
+
+	out := make(chan iface.PinLsResult)
+	go func() {
+		defer close(out)
+		for _, r := range records {
+			if settings.Type != "all" && r.typ != settings.Type {
+				continue
+			}
+			if settings.NameFilter != "" {
+				ok, err := stdpath.Match(settings.NameFilter, r.name)
+				if err != nil {
+					sendPinLsResult(ctx, out, nil, err)
+					return
+				}
+				if !ok {
+					continue
+				}
+			}
+			if !sendPinLsResult(ctx, out, newPin(r, settings.CidBase), nil) {
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// sendPinLsResult delivers a single result, honoring ctx cancellation, and
+// reports whether the stream should keep going (false on error or cancel)
+func sendPinLsResult(ctx context.Context, out chan<- iface.PinLsResult, p iface.Pin, err error) bool {
+	select {
+	case out <- &pinLsResult{pin: p, err: err}:
+		return err == nil
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (api *pinAPI) WithType(t string) options.PinLsOption {
+	return options.Pin.Type(t)
+}
+
+func (api *pinAPI) WithNameFilter(pattern string) options.PinLsOption {
+	return options.Pin.NameFilter(pattern)
+}
+
+func (api *pinAPI) Rm(ctx context.Context, p iface.Path, opts ...options.PinRmOption) error {
+	settings, err := options.PinRmOptions(opts...)
+	if err != nil {
+		return err
+	}
+	if settings.Service != "" {
+		svc, err := api.service(settings.Service)
+		if err != nil {
+			return err
+		}
+		return svc.Rm(ctx, p)
+	}
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	key := p.Cid().String()
+	if _, ok := api.pins[key]; !ok {
+		return fmt.Errorf("not pinned: %s", key)
+	}
+	delete(api.pins, key)
+	return nil
+}
+
+// Update does a native delta pin: rather than pinning to in full and then
+// unpinning from, it walks both trees, figures out which blocks of to are
+// already reachable from from (and so need no transfer), and reports the
+// difference. from's name and metadata carry over to to
+func (api *pinAPI) Update(ctx context.Context, from iface.Path, to iface.Path, opts ...options.PinUpdateOption) (*iface.PinUpdateStat, error) {
+	settings, err := options.PinUpdateOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if settings.Service != "" {
+		svc, err := api.service(settings.Service)
+		if err != nil {
+			return nil, err
+		}
+		return svc.Update(ctx, from, to, options.Pin.Unpin(settings.Unpin))
+	}
+
+	if api.walk == nil {
+		return nil, fmt.Errorf("pin update: no DagWalker configured")
+	}
+
+	api.mu.Lock()
+	_, pinned := api.pins[from.Cid().String()]
+	api.mu.Unlock()
+	if !pinned {
+		return nil, fmt.Errorf("not pinned: %s", from.Cid())
+	}
+
+	fromBlocks, err := api.walk(ctx, from.Cid())
+	if err != nil {
+		return nil, err
+	}
+	toBlocks, err := api.walk(ctx, to.Cid())
+	if err != nil {
+		return nil, err
+	}
+
+	stat := &iface.PinUpdateStat{}
+	for c, size := range toBlocks {
+		if _, reused := fromBlocks[c]; reused {
+			stat.BlocksReused++
+		} else {
+			stat.BlocksTransferred++
+			stat.BytesTransferred += size
+		}
+	}
+
+	api.mu.Lock()
+	fromRecord := api.pins[from.Cid().String()]
+	var name string
+	var meta map[string]string
+	if fromRecord != nil {
+		name, meta = fromRecord.name, fromRecord.meta
+	}
+	api.pins[to.Cid().String()] = &pinRecord{c: to, typ: "recursive", name: name, meta: meta}
+	if settings.Unpin {
+		delete(api.pins, from.Cid().String())
+	}
+	api.mu.Unlock()
+
+	return stat, nil
+}
+
+func (api *pinAPI) WithUnpin(unpin bool) options.PinUpdateOption {
+	return options.Pin.Unpin(unpin)
+}
+
+func (api *pinAPI) WithService(name string) interface {
+	options.PinAddOption
+	options.PinLsOption
+	options.PinRmOption
+	options.PinUpdateOption
+} {
+	return options.Pin.Service(name)
+}
+
+func (api *pinAPI) WithCidBase(base string) interface {
+	options.PinLsOption
+	options.PinGetOption
+} {
+	return options.Pin.CidBase(base)
+}
+
+// Verify walks every local pin with the configured DagWalker and reports
+// whether all of its blocks are reachable
+func (api *pinAPI) Verify(ctx context.Context) (<-chan iface.PinStatus, error) {
+	api.mu.Lock()
+	records := make([]*pinRecord, 0, len(api.pins))
+	for _, r := range api.pins {
+		records = append(records, r)
+	}
+	api.mu.Unlock()
+
+	out := make(chan iface.PinStatus)
+	go func() {
+		defer close(out)
+		for _, r := range records {
+			status := &pinStatus{ok: true}
+			if api.walk != nil {
+				if _, err := api.walk(ctx, r.c.Cid()); err != nil {
+					status = &pinStatus{ok: false, err: err}
+				}
+			}
+			select {
+			case out <- status:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+type pinStatus struct {
+	ok  bool
+	err error
+}
+
+func (s *pinStatus) Ok() bool                     { return s.ok }
+func (s *pinStatus) BadNodes() []iface.BadPinNode { return nil }
+func (s *pinStatus) Err() error                   { return s.err }
+
+// pinServicesAPI is the PinServicesAPI backing pinAPI.PinServices(); it shares
+// pinAPI's services map directly rather than copying it
+type pinServicesAPI pinAPI
+
+func (s *pinServicesAPI) Add(ctx context.Context, name string, service iface.PinService) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.services[name]; ok {
+		return fmt.Errorf("pinning service %q already registered", name)
+	}
+	s.services[name] = service
+	return nil
+}
+
+func (s *pinServicesAPI) Remove(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.services[name]; !ok {
+		return fmt.Errorf("unknown pinning service: %q", name)
+	}
+	delete(s.services, name)
+	return nil
+}
+
+func (s *pinServicesAPI) List(ctx context.Context) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := make([]string, 0, len(s.services))
+	for name := range s.services {
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+type pin struct {
+	p    iface.Path
+	typ  string
+	name string
+	meta map[string]string
+}
+
+func newPin(r *pinRecord, cidBase string) iface.Pin {
+	p := r.c
+	if cidBase != "" {
+		p = withCidBase(p, cidBase)
+	}
+	return &pin{p: p, typ: r.typ, name: r.name, meta: r.meta}
+}
+
+func (p *pin) Path() iface.Path            { return p.p }
+func (p *pin) Type() string                { return p.typ }
+func (p *pin) Name() string                { return p.name }
+func (p *pin) Metadata() map[string]string { return p.meta }
+
+type pinLsResult struct {
+	pin iface.Pin
+	err error
+}
+
+func (r *pinLsResult) Pin() iface.Pin { return r.pin }
+func (r *pinLsResult) Err() error     { return r.err }
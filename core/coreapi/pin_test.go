@@ -0,0 +1,284 @@
+package coreapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	iface "github.com/ipfs/go-ipfs/core/coreapi/interface"
+	options "github.com/ipfs/go-ipfs/core/coreapi/interface/options"
+
+	cid "gx/ipfs/QmeSrf6pzut73u6zLQkRFQ3ygt3k6XFT2kjdYP8Tnkwwyg/go-cid"
+)
+
+// two well-known example CIDs (the "ipfs add" output for the canonical
+// "hello world" and quick-start text files), used here only as distinct,
+// well-formed identifiers - their content is never fetched in these tests
+const (
+	testCidA = "QmUNLLsPACCz1vLxQVkXqqLX5R1X345qqfHbsf67hvA3Nn"
+	testCidB = "QmSnuWmxptJZdLJpKRarxBMS2Ju2oANVrgbr2xWbie9b2D"
+)
+
+func mustPath(t *testing.T, s string) iface.Path {
+	t.Helper()
+	c, err := cid.Decode(s)
+	if err != nil {
+		t.Fatalf("decoding test cid %q: %v", s, err)
+	}
+	return newPath(c, nil)
+}
+
+func TestPinAddNameAndMetaRoundTrip(t *testing.T) {
+	api := NewPinAPI(nil)
+	ctx := context.Background()
+	p := mustPath(t, testCidA)
+
+	meta := map[string]string{"app": "assets"}
+	if err := api.Add(ctx, p, api.WithName("assets/logo"), api.WithMeta(meta)); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	got, err := api.Get(ctx, "assets/logo")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Name() != "assets/logo" {
+		t.Fatalf("Name() = %q, want %q", got.Name(), "assets/logo")
+	}
+	if got.Metadata()["app"] != "assets" {
+		t.Fatalf("Metadata()[%q] = %q, want %q", "app", got.Metadata()["app"], "assets")
+	}
+	if got.Path().Cid().String() != p.Cid().String() {
+		t.Fatalf("Path().Cid() = %s, want %s", got.Path().Cid(), p.Cid())
+	}
+}
+
+func TestPinLsNameFilter(t *testing.T) {
+	api := NewPinAPI(nil)
+	ctx := context.Background()
+
+	if err := api.Add(ctx, mustPath(t, testCidA), api.WithName("backup/2024-01")); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := api.Add(ctx, mustPath(t, testCidB), api.WithName("assets/logo")); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	ch, err := api.Ls(ctx, api.WithNameFilter("backup/*"))
+	if err != nil {
+		t.Fatalf("Ls: %v", err)
+	}
+	var names []string
+	for r := range ch {
+		if r.Err() != nil {
+			t.Fatalf("unexpected Ls error: %v", r.Err())
+		}
+		names = append(names, r.Pin().Name())
+	}
+	if len(names) != 1 || names[0] != "backup/2024-01" {
+		t.Fatalf("Ls with name filter returned %v, want [backup/2024-01]", names)
+	}
+}
+
+func TestPinLsStopsAtFirstError(t *testing.T) {
+	api := NewPinAPI(nil)
+	ctx := context.Background()
+
+	if err := api.Add(ctx, mustPath(t, testCidA), api.WithName("ok")); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := api.Add(ctx, mustPath(t, testCidB), api.WithName("ok2")); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	// an invalid glob pattern makes path.Match error out on the first pin
+	// it's asked to test; Ls must surface that as a terminal PinLsResult
+	// rather than panicking or silently dropping it
+	ch, err := api.Ls(ctx, api.WithNameFilter("["))
+	if err != nil {
+		t.Fatalf("Ls: %v", err)
+	}
+
+	var sawErr bool
+	var results int
+	for r := range ch {
+		results++
+		if r.Err() != nil {
+			sawErr = true
+		}
+	}
+	if !sawErr {
+		t.Fatal("expected Ls to emit a result with Err() set for the malformed pattern")
+	}
+	if results != 1 {
+		t.Fatalf("Ls emitted %d results, want only the terminal error result", results)
+	}
+}
+
+func TestPinRm(t *testing.T) {
+	api := NewPinAPI(nil)
+	ctx := context.Background()
+	p := mustPath(t, testCidA)
+
+	if err := api.Add(ctx, p, api.WithName("tmp")); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := api.Rm(ctx, p); err != nil {
+		t.Fatalf("Rm: %v", err)
+	}
+	if _, err := api.Get(ctx, "tmp"); err == nil {
+		t.Fatal("expected Get to fail for a pin removed via Rm")
+	}
+}
+
+func TestPinUpdateStat(t *testing.T) {
+	shared := "QmShared1111111111111111111111111111111111"
+	fromOnly := "QmFromOnly111111111111111111111111111111111"
+	toOnly := "QmToOnly11111111111111111111111111111111111"
+
+	walk := func(ctx context.Context, root *cid.Cid) (map[string]uint64, error) {
+		switch root.String() {
+		case testCidA:
+			return map[string]uint64{shared: 10, fromOnly: 5}, nil
+		case testCidB:
+			return map[string]uint64{shared: 10, toOnly: 7}, nil
+		default:
+			t.Fatalf("walk called with unexpected root %s", root)
+			return nil, nil
+		}
+	}
+
+	api := NewPinAPI(walk).(*pinAPI)
+	ctx := context.Background()
+	from := mustPath(t, testCidA)
+	to := mustPath(t, testCidB)
+
+	if err := api.Add(ctx, from, api.WithName("site")); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	stat, err := api.Update(ctx, from, to)
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if stat.BlocksReused != 1 {
+		t.Errorf("BlocksReused = %d, want 1", stat.BlocksReused)
+	}
+	if stat.BlocksTransferred != 1 {
+		t.Errorf("BlocksTransferred = %d, want 1", stat.BlocksTransferred)
+	}
+	if stat.BytesTransferred != 7 {
+		t.Errorf("BytesTransferred = %d, want 7", stat.BytesTransferred)
+	}
+
+	// the name given to from should carry over to to by default
+	if _, err := api.Get(ctx, "site"); err != nil {
+		t.Fatalf("Get(site): %v", err)
+	}
+
+	// default WithUnpin(true) behavior: from should no longer be pinned
+	if _, ok := api.pins[from.Cid().String()]; ok {
+		t.Fatal("Update should have unpinned from by default")
+	}
+}
+
+func TestPinUpdateRejectsUnpinnedFrom(t *testing.T) {
+	walk := func(ctx context.Context, root *cid.Cid) (map[string]uint64, error) {
+		t.Fatal("walk should not be called when from isn't pinned")
+		return nil, nil
+	}
+
+	api := NewPinAPI(walk).(*pinAPI)
+	ctx := context.Background()
+	from := mustPath(t, testCidA)
+	to := mustPath(t, testCidB)
+
+	if _, err := api.Update(ctx, from, to); err == nil {
+		t.Fatal("expected Update to fail when from was never pinned")
+	}
+	if _, ok := api.pins[to.Cid().String()]; ok {
+		t.Fatal("Update should not have pinned to when from wasn't pinned")
+	}
+}
+
+// fakePinService is a minimal iface.PinService that only tracks Add calls,
+// used to confirm WithService dispatches there instead of the local pinner
+type fakePinService struct {
+	iface.PinService
+	added []string
+}
+
+func (s *fakePinService) Add(ctx context.Context, p iface.Path, opts ...options.PinAddOption) error {
+	s.added = append(s.added, p.Cid().String())
+	return nil
+}
+
+func TestPinWithServiceDispatch(t *testing.T) {
+	api := NewPinAPI(nil).(*pinAPI)
+	ctx := context.Background()
+	svc := &fakePinService{}
+
+	if err := api.PinServices().Add(ctx, "remote", svc); err != nil {
+		t.Fatalf("PinServices().Add: %v", err)
+	}
+
+	p := mustPath(t, testCidA)
+	if err := api.Add(ctx, p, api.WithService("remote")); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if len(svc.added) != 1 || svc.added[0] != p.Cid().String() {
+		t.Fatalf("fakePinService.added = %v, want [%s]", svc.added, p.Cid())
+	}
+	if _, ok := api.pins[p.Cid().String()]; ok {
+		t.Fatal("Add with WithService should not touch the local pinset")
+	}
+}
+
+// TestPinWithServiceStripsServiceOption guards against re-forwarding the
+// WithService option itself to the backend: if the backend is (like here) a
+// real PinAPI with its own empty services map, re-parsing WithService out of
+// the forwarded opts would make it try to dispatch to itself and fail
+func TestPinWithServiceStripsServiceOption(t *testing.T) {
+	main := NewPinAPI(nil).(*pinAPI)
+	backend := NewPinAPI(nil)
+	ctx := context.Background()
+
+	if err := main.PinServices().Add(ctx, "remote", backend); err != nil {
+		t.Fatalf("PinServices().Add: %v", err)
+	}
+
+	p := mustPath(t, testCidA)
+	if err := main.Add(ctx, p, main.WithName("x"), main.WithService("remote")); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := backend.Get(ctx, "x"); err != nil {
+		t.Fatalf("backend.Get: %v", err)
+	}
+}
+
+func TestPinGetWithCidBase(t *testing.T) {
+	api := NewPinAPI(nil)
+	ctx := context.Background()
+	p := mustPath(t, testCidA)
+
+	if err := api.Add(ctx, p, api.WithName("x")); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	got, err := api.Get(ctx, "x", api.WithCidBase("base32"))
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	rendered := got.Path().String()
+	if !strings.HasPrefix(rendered, "/ipfs/b") {
+		t.Fatalf("Path().String() = %q, want a base32 (\"b\"-prefixed) CID", rendered)
+	}
+
+	plain, err := api.Get(ctx, "x")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if plain.Path().String() == rendered {
+		t.Fatal("Get without WithCidBase should render the original (base58btc) CID, not base32")
+	}
+}